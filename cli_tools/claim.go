@@ -0,0 +1,131 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+
+	"github.com/inwecrypto/neogo/rpc"
+	neotx "github.com/inwecrypto/neogo/tx"
+)
+
+func init() {
+	registerCommand(&command{
+		Name:  "claim",
+		Usage: "calculate the claimable GAS of spent NEO utxos offline and sign the claim transaction",
+		Run:   runClaim,
+	})
+}
+
+func runClaim(args []string) error {
+	flags := flag.NewFlagSet("claim", flag.ExitOnError)
+
+	claimsFile := flags.String("claims", "", "json array of the spent NEO utxos, with txid, vout, block and spentBlock")
+	sysfeeFile := flags.String("sysfee", "", "json object of block height to getblocksysfee sum, for the heights before the utxo blocks")
+	node := flags.String("rpc", "", "NEO node url to read the system fees from instead of -sysfee")
+	keystoreFile := flags.String("keystore", "", "keystore or NEP-2 file, sign the claim (default only print the amount)")
+	to := flags.String("to", "", "GAS address (default the keystore address)")
+
+	flags.Parse(args)
+
+	if *claimsFile == "" {
+		return errors.New("-claims utxos file is required")
+	}
+
+	data, err := ioutil.ReadFile(*claimsFile)
+
+	if err != nil {
+		return err
+	}
+
+	var claims []*rpc.UTXO
+
+	if err := json.Unmarshal(data, &claims); err != nil {
+		return fmt.Errorf("%s: %s", *claimsFile, err)
+	}
+
+	var systemFee neotx.SystemFeeSum
+
+	if *node != "" {
+		systemFee = rpc.NewClient(*node).GetBlockSysFee
+	} else if *sysfeeFile != "" {
+		if systemFee, err = readSystemFees(*sysfeeFile); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprintln(os.Stderr, "warning: no system fees, nodes reject the claim if the blocks paid any")
+	}
+
+	if *keystoreFile == "" {
+		amount, _, err := neotx.CalcClaim(claims, systemFee)
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(os.Stderr, "claimable GAS: %s\n", amount.String())
+
+		return nil
+	}
+
+	key, err := readNEOKey(*keystoreFile)
+
+	if err != nil {
+		return err
+	}
+
+	if *to == "" {
+		*to = key.Address
+	}
+
+	tx := neotx.NewClaimTx()
+
+	amount, err := tx.ClaimOffline(*to, claims, systemFee)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "claimable GAS: %s\n", amount.String())
+
+	rawTx, txid, err := tx.Tx().Sign(key.PrivateKey)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(os.Stderr, "txid: "+txid)
+	fmt.Println(hex.EncodeToString(rawTx))
+
+	return nil
+}
+
+// readSystemFees read system fee sums from json object of height to sum
+func readSystemFees(file string) (neotx.SystemFeeSum, error) {
+	data, err := ioutil.ReadFile(file)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var fees map[string]int64
+
+	if err := json.Unmarshal(data, &fees); err != nil {
+		return nil, fmt.Errorf("%s: %s", file, err)
+	}
+
+	return func(height int64) (int64, error) {
+		fee, ok := fees[strconv.FormatInt(height, 10)]
+
+		if !ok {
+			return 0, fmt.Errorf("%s has no system fee sum of block %d", file, height)
+		}
+
+		return fee, nil
+	}, nil
+}
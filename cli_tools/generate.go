@@ -0,0 +1,146 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/inwecrypto/bip39"
+	neokeystore "github.com/inwecrypto/neogo/keystore"
+)
+
+func init() {
+	registerCommand(&command{
+		Name:  "generate",
+		Usage: "generate a new mnemonic from crypto/rand, dice rolls, coin flips or hex entropy",
+		Run:   runGenerate,
+	})
+}
+
+func runGenerate(args []string) error {
+	flags := flag.NewFlagSet("generate", flag.ExitOnError)
+
+	source := flags.String("source", "random", "entropy source random, dice, coins or hex, user entropy is read from stdin")
+	bits := flags.Int("bits", 256, "entropy bits 128, 160, 192, 224 or 256, inwecrypto mnemonics use 256")
+	mix := flags.Bool("mix", false, "mix user entropy with crypto/rand through sha256")
+	lang := flags.String("lang", "en_US", "Mnemonic language en_US, zh_CN, zh_TW, ja_JP, ko_KR, es_ES, fr_FR, it_IT, cs_CZ or pt_BR")
+	keystoreFile := flags.String("keystore", "", "also write the InWeCrypto NEO key of the mnemonic to this keystore file")
+	kdf := flags.String("kdf", "scrypt", kdfUsage)
+
+	flags.Parse(args)
+
+	dict, ok := bip39.GetDict(*lang)
+
+	if !ok {
+		return fmt.Errorf("%s %s", bip39.ErrUnknownLanguage, *lang)
+	}
+
+	if *bits > 256 {
+		return errors.New("BIP39 mnemonics hold at most 256 bits of entropy")
+	}
+
+	if *mix && *source == "random" {
+		return errors.New("-mix needs a dice, coins or hex -source")
+	}
+
+	var attrs map[string]interface{}
+
+	if *keystoreFile != "" {
+		if *bits != 256 {
+			return errors.New("-keystore needs a 256 bits InWeCrypto mnemonic")
+		}
+
+		var err error
+
+		if attrs, err = keystoreAttrs(*kdf); err != nil {
+			return err
+		}
+	}
+
+	entropy, entropyBits, err := readEntropy(*source, *bits)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "entropy: %.1f bits from %s, %d bits used\n", entropyBits, *source, *bits)
+
+	if *mix {
+		if entropy, err = bip39.MixEntropy(entropy); err != nil {
+			return err
+		}
+
+		fmt.Fprintln(os.Stderr, "entropy: mixed with crypto/rand, the mnemonic can not be recreated from the user entropy alone")
+	}
+
+	mnemonic, err := bip39.NewMnemonic(entropy, dict)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(mnemonic)
+
+	if *keystoreFile == "" {
+		return nil
+	}
+
+	key, err := neokeystore.KeyFromPrivateKey(entropy)
+
+	if err != nil {
+		return err
+	}
+
+	password, err := readNewPassword("Keystore password: ")
+
+	if err != nil {
+		return err
+	}
+
+	data, err := neokeystore.WriteKeyStore(key, password, attrs)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(os.Stderr, "neo address: "+key.Address)
+
+	return writeKeyStoreFile(*keystoreFile, data)
+}
+
+// readEntropy read user entropy of source from stdin without echo, it stays out of the shell history
+func readEntropy(source string, bits int) ([]byte, float64, error) {
+	switch source {
+	case "random":
+		entropy, err := bip39.NewEntropy(bits)
+
+		return entropy, float64(bits), err
+	case "dice":
+		rolls, err := readPassword(fmt.Sprintf("Dice rolls 1-6 (at least %d): ", bip39.MinDiceRolls(bits)))
+
+		if err != nil {
+			return nil, 0, err
+		}
+
+		return bip39.EntropyFromDice(rolls, bits)
+	case "coins":
+		flips, err := readPassword(fmt.Sprintf("Coin flips 0/1 or h/t (exactly %d): ", bits))
+
+		if err != nil {
+			return nil, 0, err
+		}
+
+		return bip39.EntropyFromCoins(flips, bits)
+	case "hex":
+		digits, err := readPassword(fmt.Sprintf("Hex entropy (exactly %d digits): ", bits/4))
+
+		if err != nil {
+			return nil, 0, err
+		}
+
+		return bip39.EntropyFromHex(digits, bits)
+	}
+
+	return nil, 0, fmt.Errorf("unknown entropy source %s, expect random, dice, coins or hex", source)
+}
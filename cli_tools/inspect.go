@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/inwecrypto/neogo/script"
+	neotx "github.com/inwecrypto/neogo/tx"
+)
+
+func init() {
+	registerCommand(&command{
+		Name:  "inspect",
+		Usage: "decode a NEO raw transaction or invocation script, showing the disassembly and contract calls",
+		Run:   runInspect,
+	})
+
+	registerCommand(&command{
+		Name:  "assemble",
+		Usage: "encode a NeoVM script listing, as printed by inspect -script, to hex",
+		Run:   runAssemble,
+	})
+}
+
+func runInspect(args []string) error {
+	flags := flag.NewFlagSet("inspect", flag.ExitOnError)
+
+	txHex := flags.String("tx", "", "raw transaction hex")
+	scriptHex := flags.String("script", "", "invocation script hex")
+	asJSON := flags.Bool("json", false, "print the -script instructions and calls as json, transactions are always json")
+
+	flags.Parse(args)
+
+	if *scriptHex != "" {
+		data, err := hex.DecodeString(strings.TrimPrefix(*scriptHex, "0x"))
+
+		if err != nil {
+			return err
+		}
+
+		instructions, err := script.Disassemble(data)
+
+		if err != nil {
+			return err
+		}
+
+		calls, _ := neotx.DecodeInvocationScript(data)
+
+		if *asJSON {
+			if calls == nil {
+				calls = []*neotx.InvocationCall{}
+			}
+
+			callsJSON, err := json.Marshal(calls)
+
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("{\"instructions\":%s,\"calls\":%s}\n", script.ListingJSON(instructions), callsJSON)
+
+			return nil
+		}
+
+		fmt.Println(script.Listing(instructions))
+
+		for _, call := range calls {
+			fmt.Println("; call " + call.String())
+		}
+
+		return nil
+	}
+
+	if *txHex == "" {
+		return errors.New("-tx raw transaction or -script hex is required")
+	}
+
+	tx, err := neotx.ReadTransactionHex(*txHex)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(tx.String())
+
+	return nil
+}
+
+func runAssemble(args []string) error {
+	flags := flag.NewFlagSet("assemble", flag.ExitOnError)
+
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		return errors.New("listing file is required")
+	}
+
+	text, err := ioutil.ReadFile(flags.Arg(0))
+
+	if err != nil {
+		return err
+	}
+
+	data, err := script.Assemble(string(text))
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(hex.EncodeToString(data))
+
+	return nil
+}
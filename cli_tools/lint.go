@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	inwekeystore "github.com/inwecrypto/keystore"
+)
+
+func init() {
+	registerCommand(&command{
+		Name:  "lint",
+		Usage: "audit keystore files or directories for weak kdf settings and damaged fields, without a password",
+		Run:   runLint,
+	})
+}
+
+func runLint(args []string) error {
+	flags := flag.NewFlagSet("lint", flag.ExitOnError)
+
+	jsonOutput := flags.Bool("json", false, "print the reports as json")
+
+	flags.Parse(args)
+
+	if flags.NArg() == 0 {
+		return errors.New("keystore files or directories are required")
+	}
+
+	var reports []*inwekeystore.Report
+
+	for _, path := range flags.Args() {
+		info, err := os.Stat(path)
+
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			dirReports, err := inwekeystore.LintDirectory(path)
+
+			if err != nil {
+				return err
+			}
+
+			reports = append(reports, dirReports...)
+
+			continue
+		}
+
+		data, err := ioutil.ReadFile(path)
+
+		if err != nil {
+			return err
+		}
+
+		reports = append(reports, inwekeystore.Lint(path, data))
+	}
+
+	// salts may also be shared across the given directories and files
+	inwekeystore.CheckDuplicateSalts(reports)
+
+	if *jsonOutput {
+		data, err := json.MarshalIndent(reports, "", "  ")
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(string(data))
+	} else {
+		for _, report := range reports {
+			fmt.Print(report.String())
+		}
+	}
+
+	failed := 0
+
+	for _, report := range reports {
+		if report.Severity() == inwekeystore.SeverityError {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d keystores have errors", failed, len(reports))
+	}
+
+	return nil
+}
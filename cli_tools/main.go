@@ -2,22 +2,55 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"io/ioutil"
 	"os"
+	"sort"
+	"strings"
 
+	"github.com/inwecrypto/bip32"
+	"github.com/inwecrypto/bip39"
+	"github.com/inwecrypto/mobilesdk/ethmobile"
 	"github.com/inwecrypto/mobilesdk/neomobile"
+	neokeystore "github.com/inwecrypto/neogo/keystore"
 )
 
-func init() {
-	flag.Parse()
+// command keytool sub command
+type command struct {
+	Name  string                    // command name, the first cli argument
+	Usage string                    // one line usage description
+	Run   func(args []string) error // command entry, args exclude the command name
+}
+
+var commands = map[string]*command{}
+
+func registerCommand(cmd *command) {
+	commands[cmd.Name] = cmd
 }
 
 var keystore = flag.String("keystore", "", "Keystore file path")
 var psword = flag.String("password", "", "Keystore password")
 var mnemonic = flag.String("mnemonic", "", "Mnemonic string")
-var lang = flag.String("lang", "en_US", "Mnemonic language en_US or zh_CN")
+var lang = flag.String("lang", "", "Mnemonic language en_US, zh_CN, zh_TW, ja_JP, ko_KR, es_ES, fr_FR, it_IT, cs_CZ or pt_BR (detected when empty)")
+var chain = flag.String("chain", "neo", "Key chain neo or eth, selects the bip44 curve and default path")
+var derivation = flag.String("derivation", "inwecrypto", "Mnemonic derivation inwecrypto or bip44 (ledger, metamask, neon, o3)")
+var path = flag.String("path", "", "bip44 derivation path (default m/44'/888'/0'/0/0 for neo, m/44'/60'/0'/0/0 for eth)")
 
 func main() {
+	if len(os.Args) > 1 {
+		if cmd, ok := commands[os.Args[1]]; ok {
+			if err := cmd.Run(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %s\n", cmd.Name, err)
+				os.Exit(1)
+			}
+
+			return
+		}
+	}
+
+	flag.Usage = usage
+	flag.Parse()
+
 	if (*keystore != "") && (*psword != "") {
 		fi, err := os.Open(*keystore)
 		if err != nil {
@@ -29,17 +62,19 @@ func main() {
 			panic(err)
 		}
 
-		prkey, err := neomobile.FromKeyStore(string(keystring), *psword)
+		prkey, err := fromKeyStore(string(keystring))
 
 		if err != nil {
-			println(err)
+			println(err.Error())
+			os.Exit(1)
 		}
 		println("\n\n private key: " + prkey)
-	} else if (*mnemonic != "") && (*lang != "") {
-		prkey, err := neomobile.FromMnemonic(*mnemonic, *lang)
+	} else if *mnemonic != "" {
+		prkey, err := fromMnemonic()
 
 		if err != nil {
-			println(err)
+			println(err.Error())
+			os.Exit(1)
 		}
 		println("\n\n private key: " + prkey)
 	} else {
@@ -47,3 +82,123 @@ func main() {
 	}
 
 }
+
+// fromKeyStore read the private key, -chain eth also opens geth v1 and presale wallets,
+// a neo keystore file may also hold a NEP-2 encrypted key
+func fromKeyStore(ks string) (string, error) {
+	if *chain == "eth" {
+		wallet, err := ethmobile.FromKeyStore(ks, *psword)
+
+		if err != nil {
+			return "", err
+		}
+
+		println("address: " + wallet.Address())
+
+		return wallet.PrivateKey(), nil
+	}
+
+	if neokeystore.IsNEP2(strings.TrimSpace(ks)) {
+		wallet, err := neomobile.FromNEP2(ks, *psword)
+
+		if err != nil {
+			return "", err
+		}
+
+		println("address: " + wallet.Address())
+
+		return wallet.PrivateKey(), nil
+	}
+
+	return neomobile.FromKeyStore(ks, *psword)
+}
+
+func fromMnemonic() (string, error) {
+	if *lang == "" {
+		detection, err := bip39.DetectLanguage(*mnemonic)
+
+		if err != nil {
+			return "", fmt.Errorf("%s, use -lang to choose one", err)
+		}
+
+		println(fmt.Sprintf("mnemonic language: %s (detected, %.0f%% words matched)", detection.Lang, detection.Confidence*100))
+
+		*lang = detection.Lang
+	}
+
+	if *derivation == "inwecrypto" {
+		return neomobile.FromMnemonic(*mnemonic, *lang)
+	}
+
+	if *derivation != "bip44" {
+		return "", fmt.Errorf("unknown derivation %s, expect inwecrypto or bip44", *derivation)
+	}
+
+	passphrase, err := readPassword("Mnemonic passphrase (empty for none): ")
+
+	if err != nil {
+		return "", err
+	}
+
+	derivationPath := *path
+
+	switch *chain {
+	case "neo":
+		if derivationPath == "" {
+			derivationPath = bip32.BIP44Path(bip32.CoinTypeNEO, 0, 0).String()
+		}
+
+		key, err := neomobile.FromMnemonicPath(*mnemonic, *lang, derivationPath, passphrase)
+
+		if err != nil {
+			return "", err
+		}
+
+		printHDKey(key.Fingerprint, derivationPath, key.Address)
+
+		return key.PrivateKey, nil
+	case "eth":
+		if derivationPath == "" {
+			derivationPath = bip32.BIP44Path(bip32.CoinTypeETH, 0, 0).String()
+		}
+
+		wallet, err := ethmobile.FromMnemonicPath(*mnemonic, *lang, derivationPath, passphrase)
+
+		if err != nil {
+			return "", err
+		}
+
+		printHDKey(wallet.Fingerprint(), derivationPath, wallet.Address())
+
+		return wallet.PrivateKey(), nil
+	}
+
+	return "", fmt.Errorf("unknown chain %s, expect neo or eth", *chain)
+}
+
+// printHDKey print the values users can compare with their original wallet,
+// a mistyped passphrase silently derives a different wallet
+func printHDKey(fingerprint, derivationPath, address string) {
+	println("master fingerprint: " + fingerprint)
+	println("derivation path: " + derivationPath)
+	println("address: " + address)
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage of %s:\n", os.Args[0])
+	flag.PrintDefaults()
+
+	var names []string
+
+	for name := range commands {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	fmt.Fprintf(os.Stderr, "\nCommands (%s <command> -h for details):\n", os.Args[0])
+
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", name, commands[name].Usage)
+	}
+}
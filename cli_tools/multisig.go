@@ -0,0 +1,354 @@
+package main
+
+import (
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	neokeystore "github.com/inwecrypto/neogo/keystore"
+	neotx "github.com/inwecrypto/neogo/tx"
+)
+
+func init() {
+	registerCommand(&command{
+		Name:  "multisig-pubkey",
+		Usage: "print the public key of a keystore, to share with the other multi-signature key holders",
+		Run:   runMultiSigPubKey,
+	})
+
+	registerCommand(&command{
+		Name:  "multisig-address",
+		Usage: "create a NEO M-of-N multi-signature address from public keys",
+		Run:   runMultiSigAddress,
+	})
+
+	registerCommand(&command{
+		Name:  "multisig-context",
+		Usage: "create the signing context of an unsigned multi-signature transaction",
+		Run:   runMultiSigContext,
+	})
+
+	registerCommand(&command{
+		Name:  "multisig-sign",
+		Usage: "add the signature of a keystore to a signing context, offline",
+		Run:   runMultiSigSign,
+	})
+
+	registerCommand(&command{
+		Name:  "multisig-combine",
+		Usage: "combine signing contexts into the signed raw transaction",
+		Run:   runMultiSigCombine,
+	})
+}
+
+func runMultiSigPubKey(args []string) error {
+	flags := flag.NewFlagSet("multisig-pubkey", flag.ExitOnError)
+
+	keystoreFile := flags.String("keystore", "", "keystore or NEP-2 file")
+
+	flags.Parse(args)
+
+	key, err := readNEOKey(*keystoreFile)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(os.Stderr, "address: "+key.Address)
+	fmt.Println(hex.EncodeToString(neotx.PublicKeyBytes(&key.PrivateKey.PublicKey)))
+
+	return nil
+}
+
+func runMultiSigAddress(args []string) error {
+	flags := flag.NewFlagSet("multisig-address", flag.ExitOnError)
+
+	m := flags.Int("m", 0, "number of signatures needed")
+
+	flags.Parse(args)
+
+	publicKeys, err := parsePublicKeys(flags.Args())
+
+	if err != nil {
+		return err
+	}
+
+	verification, err := neotx.MultiSigScript(*m, publicKeys)
+
+	if err != nil {
+		return err
+	}
+
+	address, err := neotx.MultiSigAddress(*m, publicKeys)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("address: %s\nscript: %s\n", address, hex.EncodeToString(verification))
+
+	return nil
+}
+
+func runMultiSigContext(args []string) error {
+	flags := flag.NewFlagSet("multisig-context", flag.ExitOnError)
+
+	txHex := flags.String("tx", "", "unsigned transaction hex, without witnesses")
+	m := flags.Int("m", 0, "number of signatures needed")
+	out := flags.String("out", "", "signing context file (default stdout)")
+
+	flags.Parse(args)
+
+	if *txHex == "" {
+		return errors.New("-tx unsigned transaction hex is required")
+	}
+
+	publicKeys, err := parsePublicKeys(flags.Args())
+
+	if err != nil {
+		return err
+	}
+
+	verification, err := neotx.MultiSigScript(*m, publicKeys)
+
+	if err != nil {
+		return err
+	}
+
+	context, err := neotx.NewSigningContextFromHex(strings.TrimPrefix(*txHex, "0x"), verification)
+
+	if err != nil {
+		return err
+	}
+
+	return writeSigningContext(*out, context)
+}
+
+func runMultiSigSign(args []string) error {
+	flags := flag.NewFlagSet("multisig-sign", flag.ExitOnError)
+
+	in := flags.String("in", "", "signing context file")
+	keystoreFile := flags.String("keystore", "", "keystore or NEP-2 file of one of the signers")
+	out := flags.String("out", "", "signed context file (default overwrite -in)")
+
+	flags.Parse(args)
+
+	context, err := readSigningContext(*in)
+
+	if err != nil {
+		return err
+	}
+
+	address, err := context.Address()
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "signing transaction %s of %s\n", context.TxID, address)
+
+	if err := printSignData(context.Hex); err != nil {
+		return err
+	}
+
+	key, err := readNEOKey(*keystoreFile)
+
+	if err != nil {
+		return err
+	}
+
+	if err := context.Sign(key.PrivateKey); err != nil {
+		return err
+	}
+
+	missing, err := context.Missing()
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "%d more signatures needed\n", missing)
+
+	if *out == "" {
+		*out = *in
+	}
+
+	return writeSigningContext(*out, context)
+}
+
+// printSignData show the co-signer what they approve: the inputs, outputs and contract calls of the transaction
+func printSignData(signData string) error {
+	data, err := hex.DecodeString(signData)
+
+	if err != nil {
+		return err
+	}
+
+	// sign data is the raw transaction without its witness list
+	tx, err := neotx.ReadTransaction(append(data, 0x00))
+
+	if err != nil {
+		return err
+	}
+
+	for _, vin := range tx.Inputs {
+		fmt.Fprintf(os.Stderr, "  input  %s:%d\n", vin.Tx, vin.N)
+	}
+
+	for _, vout := range tx.Outputs {
+		fmt.Fprintf(os.Stderr, "  output %s %s to %s\n", vout.Value.String(), assetName(vout.Asset), vout.Address)
+	}
+
+	if tx.Type != neotx.InvocationTransaction {
+		return nil
+	}
+
+	calls, err := (*neotx.InvocationTx)(tx).Calls()
+
+	if err != nil {
+		return err
+	}
+
+	for _, call := range calls {
+		fmt.Fprintf(os.Stderr, "  call   %s\n", call.String())
+	}
+
+	return nil
+}
+
+func assetName(asset string) string {
+	switch asset {
+	case neotx.NEOAssert:
+		return "NEO"
+	case neotx.GasAssert:
+		return "GAS"
+	}
+
+	return asset
+}
+
+func runMultiSigCombine(args []string) error {
+	flags := flag.NewFlagSet("multisig-combine", flag.ExitOnError)
+
+	flags.Parse(args)
+
+	if flags.NArg() == 0 {
+		return errors.New("signing context files are required")
+	}
+
+	context, err := readSigningContext(flags.Arg(0))
+
+	if err != nil {
+		return err
+	}
+
+	for _, file := range flags.Args()[1:] {
+		other, err := readSigningContext(file)
+
+		if err != nil {
+			return err
+		}
+
+		if err := context.Merge(other); err != nil {
+			return fmt.Errorf("%s: %s", file, err)
+		}
+	}
+
+	rawTx, txid, err := context.RawTx()
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(os.Stderr, "txid: "+txid)
+	fmt.Println(hex.EncodeToString(rawTx))
+
+	return nil
+}
+
+func parsePublicKeys(args []string) ([][]byte, error) {
+	if len(args) == 0 {
+		return nil, errors.New("public keys are required")
+	}
+
+	var publicKeys [][]byte
+
+	for _, arg := range args {
+		publicKey, err := hex.DecodeString(arg)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key %s", arg)
+		}
+
+		publicKeys = append(publicKeys, publicKey)
+	}
+
+	return publicKeys, nil
+}
+
+// readNEOKey read neo key from keystore or NEP-2 file, asking the password
+func readNEOKey(file string) (*neokeystore.Key, error) {
+	if file == "" {
+		return nil, errors.New("-keystore file is required")
+	}
+
+	data, err := ioutil.ReadFile(file)
+
+	if err != nil {
+		return nil, err
+	}
+
+	password, err := readPassword("Keystore password: ")
+
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := decryptKeyStore(data, password)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return neokeystore.KeyFromPrivateKey(key.PrivateKey)
+}
+
+func readSigningContext(file string) (*neotx.SigningContext, error) {
+	if file == "" {
+		return nil, errors.New("-in signing context file is required")
+	}
+
+	data, err := ioutil.ReadFile(file)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return neotx.ReadSigningContext(data)
+}
+
+func writeSigningContext(file string, context *neotx.SigningContext) error {
+	data, err := context.JSON()
+
+	if err != nil {
+		return err
+	}
+
+	if file == "" {
+		fmt.Println(string(data))
+
+		return nil
+	}
+
+	if err := ioutil.WriteFile(file, data, 0644); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "signing context written to %s\n", file)
+
+	return nil
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	neokeystore "github.com/inwecrypto/neogo/keystore"
+)
+
+func init() {
+	registerCommand(&command{
+		Name:  "nep6-export",
+		Usage: "export InWeCrypto keystores or NEP-2 keys as one NEP-6 wallet for neon, o3 and neo-cli",
+		Run:   runNEP6Export,
+	})
+
+	registerCommand(&command{
+		Name:  "nep6-extract",
+		Usage: "extract the keys of a NEP-6 wallet as InWeCrypto keystores",
+		Run:   runNEP6Extract,
+	})
+}
+
+func runNEP6Export(args []string) error {
+	flags := flag.NewFlagSet("nep6-export", flag.ExitOnError)
+
+	out := flags.String("out", "", "NEP-6 wallet file, accounts are added if it exists")
+	name := flags.String("name", "InWeCrypto", "wallet name of a new NEP-6 wallet")
+
+	flags.Parse(args)
+
+	if *out == "" || flags.NArg() == 0 {
+		return errors.New("-out wallet file and keystore files are required")
+	}
+
+	wallet, err := neokeystore.LoadNEP6Wallet(*out)
+
+	if os.IsNotExist(err) {
+		wallet, err = neokeystore.NewNEP6Wallet(*name), nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	password, err := readNEP6Password(wallet)
+
+	if err != nil {
+		return err
+	}
+
+	for _, file := range flags.Args() {
+		data, err := ioutil.ReadFile(file)
+
+		if err != nil {
+			return err
+		}
+
+		keystorePassword, err := readPassword(fmt.Sprintf("%s password: ", file))
+
+		if err != nil {
+			return err
+		}
+
+		key, err := decryptKeyStore(data, keystorePassword)
+
+		if err != nil {
+			return fmt.Errorf("%s: %s", file, err)
+		}
+
+		neoKey, err := neokeystore.KeyFromPrivateKey(key.PrivateKey)
+
+		if err != nil {
+			return err
+		}
+
+		label := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+
+		if _, err := wallet.AddAccount(neoKey, password, label); err != nil {
+			return fmt.Errorf("%s: %s %s", file, err, neoKey.Address)
+		}
+
+		fmt.Fprintf(os.Stderr, "added %s\n", neoKey.Address)
+	}
+
+	if err := wallet.Save(*out); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "NEP-6 wallet written to %s\n", *out)
+
+	return nil
+}
+
+// readNEP6Password ask the password of an existing wallet and check it on one of its keys,
+// or a new password if the wallet holds no key yet
+func readNEP6Password(wallet *neokeystore.NEP6Wallet) (string, error) {
+	for _, account := range wallet.Accounts {
+		if account.Key == nil {
+			continue
+		}
+
+		password, err := readPassword("NEP-6 wallet password: ")
+
+		if err != nil {
+			return "", err
+		}
+
+		if _, err := wallet.DecryptAccount(account.Address, password); err != nil {
+			return "", fmt.Errorf("%s: %s", account.Address, err)
+		}
+
+		return password, nil
+	}
+
+	return readNewPassword("NEP-6 wallet password: ")
+}
+
+func runNEP6Extract(args []string) error {
+	flags := flag.NewFlagSet("nep6-extract", flag.ExitOnError)
+
+	in := flags.String("in", "", "NEP-6 wallet file")
+	address := flags.String("address", "", "account address (default all accounts)")
+	out := flags.String("out", ".", "directory of the keystores, named by address")
+	kdf := flags.String("kdf", "scrypt", kdfUsage)
+
+	flags.Parse(args)
+
+	if *in == "" {
+		return errors.New("-in NEP-6 wallet file is required")
+	}
+
+	attrs, err := keystoreAttrs(*kdf)
+
+	if err != nil {
+		return err
+	}
+
+	wallet, err := neokeystore.LoadNEP6Wallet(*in)
+
+	if err != nil {
+		return err
+	}
+
+	accounts := wallet.Accounts
+
+	if *address != "" {
+		account := wallet.Account(*address)
+
+		if account == nil {
+			return neokeystore.ErrNEP6AccountNotFound
+		}
+
+		accounts = []*neokeystore.NEP6Account{account}
+	}
+
+	password, err := readPassword("NEP-6 wallet password: ")
+
+	if err != nil {
+		return err
+	}
+
+	newPassword, err := readNewPassword("New keystore password: ")
+
+	if err != nil {
+		return err
+	}
+
+	// multi-signature accounts hold the key of one member, written once under the member address
+	extracted := make(map[string]bool)
+
+	for _, account := range accounts {
+		if account.Key == nil {
+			fmt.Fprintf(os.Stderr, "skip watch only account %s\n", account.Address)
+
+			continue
+		}
+
+		key, err := wallet.DecryptAccount(account.Address, password)
+
+		if err != nil {
+			return fmt.Errorf("%s: %s", account.Address, err)
+		}
+
+		if extracted[key.Address] {
+			continue
+		}
+
+		extracted[key.Address] = true
+
+		data, err := neokeystore.WriteKeyStore(key, newPassword, attrs)
+
+		if err != nil {
+			return err
+		}
+
+		if err := writeKeyStoreFile(filepath.Join(*out, key.Address+".json"), data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+var stdin = bufio.NewReader(os.Stdin)
+
+// readPassword read password from terminal without echo,
+// fall back to read one line from stdin when stdin is not a terminal
+func readPassword(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+
+	fd := int(os.Stdin.Fd())
+
+	if terminal.IsTerminal(fd) {
+		data, err := terminal.ReadPassword(fd)
+
+		fmt.Fprintln(os.Stderr)
+
+		return string(data), err
+	}
+
+	line, err := stdin.ReadString('\n')
+
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// readNewPassword read password twice and check they are equal
+func readNewPassword(prompt string) (string, error) {
+	password, err := readPassword(prompt)
+
+	if err != nil {
+		return "", err
+	}
+
+	if password == "" {
+		return "", errors.New("empty password")
+	}
+
+	confirm, err := readPassword("Repeat password: ")
+
+	if err != nil {
+		return "", err
+	}
+
+	if password != confirm {
+		return "", errors.New("passwords do not match")
+	}
+
+	return password, nil
+}
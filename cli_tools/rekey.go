@@ -0,0 +1,159 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	inwekeystore "github.com/inwecrypto/keystore"
+	neokeystore "github.com/inwecrypto/neogo/keystore"
+)
+
+const kdfUsage = "keystore kdf scrypt, pbkdf2 or argon2id (argon2id keystores are only readable by this keytool)"
+
+func init() {
+	registerCommand(&command{
+		Name:  "rekey",
+		Usage: "re-encrypt a keystore or NEP-2 key with a new password, kdf or format",
+		Run:   runRekey,
+	})
+}
+
+func runRekey(args []string) error {
+	flags := flag.NewFlagSet("rekey", flag.ExitOnError)
+
+	in := flags.String("in", "", "keystore or NEP-2 file to re-encrypt")
+	out := flags.String("out", "", "new keystore file (default stdout)")
+	kdf := flags.String("kdf", "scrypt", kdfUsage)
+	format := flags.String("format", "keystore", "output format keystore (web3 json) or nep2 (6P... string of neon and o3, -kdf is ignored)")
+
+	flags.Parse(args)
+
+	if *in == "" {
+		return errors.New("-in keystore file is required")
+	}
+
+	var attrs map[string]interface{}
+	var err error
+
+	switch *format {
+	case "keystore":
+		if attrs, err = keystoreAttrs(*kdf); err != nil {
+			return err
+		}
+	case "nep2":
+	default:
+		return fmt.Errorf("unknown format %s, expect keystore or nep2", *format)
+	}
+
+	data, err := ioutil.ReadFile(*in)
+
+	if err != nil {
+		return err
+	}
+
+	password, err := readPassword("Keystore password: ")
+
+	if err != nil {
+		return err
+	}
+
+	key, err := decryptKeyStore(data, password)
+
+	if err != nil {
+		return err
+	}
+
+	newPassword, err := readNewPassword("New keystore password: ")
+
+	if err != nil {
+		return err
+	}
+
+	if *format == "nep2" {
+		neoKey, err := neokeystore.KeyFromPrivateKey(key.PrivateKey)
+
+		if err != nil {
+			return err
+		}
+
+		nep2, err := neokeystore.NEP2Encrypt(neoKey, newPassword)
+
+		if err != nil {
+			return err
+		}
+
+		return writeKeyStoreFile(*out, []byte(nep2))
+	}
+
+	// the key id and address are kept, only the encryption changes
+	data, err = inwekeystore.Encrypt(key, newPassword, attrs)
+
+	if err != nil {
+		return err
+	}
+
+	return writeKeyStoreFile(*out, data)
+}
+
+// decryptKeyStore decrypt web3 json keystore or NEP-2 encrypted key
+func decryptKeyStore(data []byte, password string) (*inwekeystore.Key, error) {
+	nep2 := strings.TrimSpace(string(data))
+
+	if !neokeystore.IsNEP2(nep2) {
+		return inwekeystore.Decrypt(data, password)
+	}
+
+	key, err := neokeystore.NEP2Decrypt(nep2, password)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &inwekeystore.Key{
+		ID:         key.ID,
+		Address:    key.Address,
+		PrivateKey: key.ToBytes(),
+	}, nil
+}
+
+// keystoreAttrs Encrypt attrs of the kdf, using the strong parameters
+func keystoreAttrs(kdf string) (map[string]interface{}, error) {
+	switch kdf {
+	case "scrypt":
+		return map[string]interface{}{
+			"KDF":     kdf,
+			"ScryptN": neokeystore.StandardScryptN,
+			"ScryptP": neokeystore.StandardScryptP,
+		}, nil
+	case "pbkdf2":
+		return map[string]interface{}{"KDF": kdf}, nil
+	case "argon2id":
+		fmt.Fprintln(os.Stderr, "warning: argon2id keystores are not part of the web3 keystore standard,")
+		fmt.Fprintln(os.Stderr, "warning: geth, MetaMask, MyEtherWallet, neon and other wallets can not open them, keep the mnemonic as backup")
+
+		return map[string]interface{}{"KDF": kdf}, nil
+	}
+
+	return nil, fmt.Errorf("unknown kdf %s, expect scrypt, pbkdf2 or argon2id", kdf)
+}
+
+// writeKeyStoreFile write keystore to file readable only by the owner, or stdout if file is empty
+func writeKeyStoreFile(file string, data []byte) error {
+	if file == "" {
+		fmt.Println(string(data))
+
+		return nil
+	}
+
+	if err := ioutil.WriteFile(file, data, 0600); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "keystore written to %s\n", file)
+
+	return nil
+}
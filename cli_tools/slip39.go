@@ -0,0 +1,214 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/inwecrypto/bip39"
+	neokeystore "github.com/inwecrypto/neogo/keystore"
+	"github.com/inwecrypto/slip39"
+)
+
+func init() {
+	registerCommand(&command{
+		Name:  "slip39-split",
+		Usage: "split an InWeCrypto mnemonic into SLIP-39 share mnemonics",
+		Run:   runSlip39Split,
+	})
+
+	registerCommand(&command{
+		Name:  "slip39-recover",
+		Usage: "recover an InWeCrypto mnemonic from SLIP-39 share mnemonics",
+		Run:   runSlip39Recover,
+	})
+}
+
+func runSlip39Split(args []string) error {
+	flags := flag.NewFlagSet("slip39-split", flag.ExitOnError)
+
+	groupsFlag := flags.String("groups", "2-of-3", "comma separated member groups, eg. 1-of-1,3-of-5")
+	threshold := flags.Int("threshold", 1, "number of groups needed to recover")
+	exponent := flags.Int("exponent", 1, "passphrase pbkdf2 iteration exponent, 10000 * 2^exponent iterations")
+	lang := flags.String("lang", "", "InWeCrypto mnemonic language (detected when empty)")
+
+	flags.Parse(args)
+
+	groups, err := parseSlip39Groups(*groupsFlag)
+
+	if err != nil {
+		return err
+	}
+
+	mnemonic, err := readPassword("InWeCrypto mnemonic: ")
+
+	if err != nil {
+		return err
+	}
+
+	dict, err := bip39.FindDict(*lang, mnemonic)
+
+	if err != nil {
+		return err
+	}
+
+	if mnemonic, err = bip39.NormalizeMnemonic(mnemonic, dict); err != nil {
+		return err
+	}
+
+	masterSecret, err := bip39.MnemonicToEntropy(mnemonic, dict)
+
+	if err != nil {
+		return err
+	}
+
+	if len(masterSecret) != 32 {
+		return errors.New("InWeCrypto mnemonic has 24 words")
+	}
+
+	passphrase, err := readSlip39Passphrase(true)
+
+	if err != nil {
+		return err
+	}
+
+	shares, err := slip39.GenerateShares(*threshold, groups, masterSecret, passphrase, *exponent)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("any %d of %d groups recover the mnemonic\n", *threshold, len(groups))
+
+	for i, members := range shares {
+		fmt.Printf("\ngroup %d, any %d of %d shares:\n", i+1, groups[i].Threshold, groups[i].Count)
+
+		for j, share := range members {
+			fmt.Printf("%2d) %s\n", j+1, share)
+		}
+	}
+
+	return nil
+}
+
+func runSlip39Recover(args []string) error {
+	flags := flag.NewFlagSet("slip39-recover", flag.ExitOnError)
+
+	lang := flags.String("lang", "en_US", "language of the recovered InWeCrypto mnemonic")
+
+	flags.Parse(args)
+
+	dict, ok := bip39.GetDict(*lang)
+
+	if !ok {
+		return fmt.Errorf("%s %s", bip39.ErrUnknownLanguage, *lang)
+	}
+
+	fmt.Fprintln(os.Stderr, "Share mnemonics, one per line, empty line to finish:")
+
+	var shares []string
+
+	for {
+		line, err := stdin.ReadString('\n')
+
+		if err != nil && err != io.EOF {
+			return err
+		}
+
+		line = strings.TrimSpace(line)
+
+		if line == "" {
+			break
+		}
+
+		shares = append(shares, line)
+
+		if err == io.EOF {
+			break
+		}
+	}
+
+	passphrase, err := readSlip39Passphrase(false)
+
+	if err != nil {
+		return err
+	}
+
+	masterSecret, err := slip39.CombineMnemonics(shares, passphrase)
+
+	if err != nil {
+		return err
+	}
+
+	mnemonic, err := bip39.NewMnemonic(masterSecret, dict)
+
+	if err != nil {
+		return err
+	}
+
+	key, err := neokeystore.KeyFromPrivateKey(masterSecret)
+
+	if err != nil {
+		return err
+	}
+
+	// a mistyped passphrase silently gives a different mnemonic, the address helps to notice it
+	fmt.Println("neo address: " + key.Address)
+	fmt.Println("mnemonic: " + mnemonic)
+
+	return nil
+}
+
+// parseSlip39Groups parse groups like 1-of-1,3-of-5
+func parseSlip39Groups(value string) ([]slip39.Group, error) {
+	var groups []slip39.Group
+
+	for _, spec := range strings.Split(value, ",") {
+		parts := strings.Split(strings.TrimSpace(spec), "-of-")
+
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid group %s, expect threshold-of-count like 2-of-3", spec)
+		}
+
+		threshold, err := strconv.Atoi(parts[0])
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid group %s: %s", spec, err)
+		}
+
+		count, err := strconv.Atoi(parts[1])
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid group %s: %s", spec, err)
+		}
+
+		groups = append(groups, slip39.Group{Threshold: threshold, Count: count})
+	}
+
+	return groups, nil
+}
+
+// readSlip39Passphrase read the optional slip39 passphrase, a new passphrase is asked twice
+func readSlip39Passphrase(confirm bool) (string, error) {
+	passphrase, err := readPassword("SLIP-39 passphrase (empty for none): ")
+
+	if err != nil || passphrase == "" || !confirm {
+		return passphrase, err
+	}
+
+	repeat, err := readPassword("Repeat passphrase: ")
+
+	if err != nil {
+		return "", err
+	}
+
+	if repeat != passphrase {
+		return "", errors.New("passphrases do not match")
+	}
+
+	return passphrase, nil
+}
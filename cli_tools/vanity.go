@@ -0,0 +1,303 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	ethkeystore "github.com/inwecrypto/ethgo/keystore"
+	neokeystore "github.com/inwecrypto/neogo/keystore"
+)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+func init() {
+	registerCommand(&command{
+		Name:  "vanity",
+		Usage: "generate NEO/ETH keys whose address matches a prefix, suffix or regex",
+		Run:   runVanity,
+	})
+}
+
+// vanityKey generated key candidate
+type vanityKey struct {
+	Address string                                // key address
+	Write   func(password string) ([]byte, error) // write key as encrypted keystore
+}
+
+type vanityMatcher struct {
+	chain  string
+	prefix string
+	suffix string
+	regexp *regexp.Regexp
+}
+
+func newVanityMatcher(chain, prefix, suffix, expr string) (*vanityMatcher, error) {
+	matcher := &vanityMatcher{
+		chain:  chain,
+		prefix: prefix,
+		suffix: suffix,
+	}
+
+	switch chain {
+	case "neo":
+		if prefix != "" && !strings.HasPrefix(prefix, "A") {
+			return nil, errors.New("NEO addresses always start with 'A'")
+		}
+
+		if err := checkAlphabet(prefix+suffix, base58Alphabet, "base58 has no 0, O, I or l"); err != nil {
+			return nil, err
+		}
+	case "eth":
+		matcher.prefix = strings.ToLower(strings.TrimPrefix(strings.TrimPrefix(prefix, "0x"), "0X"))
+		matcher.suffix = strings.ToLower(suffix)
+
+		if err := checkAlphabet(matcher.prefix+matcher.suffix, "0123456789abcdef", "ETH addresses are hex"); err != nil {
+			return nil, err
+		}
+
+		if expr != "" {
+			expr = "(?i)" + expr
+		}
+	default:
+		return nil, fmt.Errorf("unsupported chain %s, expect neo or eth", chain)
+	}
+
+	if expr != "" {
+		var err error
+
+		if matcher.regexp, err = regexp.Compile(expr); err != nil {
+			return nil, err
+		}
+	}
+
+	if matcher.prefix == "" && matcher.suffix == "" && matcher.regexp == nil {
+		return nil, errors.New("one of -prefix, -suffix or -regex is required")
+	}
+
+	return matcher, nil
+}
+
+func checkAlphabet(pattern string, alphabet string, hint string) error {
+	for _, c := range pattern {
+		if !strings.ContainsRune(alphabet, c) {
+			return fmt.Errorf("character '%c' can never appear in address, %s", c, hint)
+		}
+	}
+
+	return nil
+}
+
+func (matcher *vanityMatcher) match(address string) bool {
+	if matcher.regexp != nil && !matcher.regexp.MatchString(address) {
+		return false
+	}
+
+	if matcher.chain == "eth" {
+		address = strings.ToLower(strings.TrimPrefix(address, "0x"))
+	}
+
+	return strings.HasPrefix(address, matcher.prefix) && strings.HasSuffix(address, matcher.suffix)
+}
+
+// difficulty estimate the expected attempts to find one match,
+// regex patterns can not be estimated and return false
+func (matcher *vanityMatcher) difficulty() (float64, bool) {
+	if matcher.regexp != nil {
+		return 0, false
+	}
+
+	if matcher.chain == "eth" {
+		return math.Pow(16, float64(len(matcher.prefix)+len(matcher.suffix))), true
+	}
+
+	// the leading 'A' is fixed by the address version byte
+	prefix := len(matcher.prefix)
+
+	if prefix > 0 {
+		prefix--
+	}
+
+	return math.Pow(58, float64(prefix+len(matcher.suffix))), true
+}
+
+func newVanityKey(chain string) (*vanityKey, error) {
+	if chain == "eth" {
+		key, err := ethkeystore.NewKey()
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &vanityKey{
+			Address: key.Address,
+			Write: func(password string) ([]byte, error) {
+				return ethkeystore.WriteScryptKeyStore(key, password)
+			},
+		}, nil
+	}
+
+	key, err := neokeystore.NewKey()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &vanityKey{
+		Address: key.Address,
+		Write: func(password string) ([]byte, error) {
+			return neokeystore.WriteScryptKeyStore(key, password)
+		},
+	}, nil
+}
+
+func runVanity(args []string) error {
+	flags := flag.NewFlagSet("vanity", flag.ExitOnError)
+
+	chain := flags.String("chain", "neo", "address type neo or eth")
+	prefix := flags.String("prefix", "", "address prefix, NEO prefix includes the leading 'A'")
+	suffix := flags.String("suffix", "", "address suffix")
+	expr := flags.String("regex", "", "address regular expression, case-insensitive for eth")
+	count := flags.Int("count", 1, "number of matched keys to generate")
+	workers := flags.Int("workers", runtime.NumCPU(), "number of parallel generators")
+	out := flags.String("out", ".", "output directory of generated keystore files")
+
+	flags.Parse(args)
+
+	matcher, err := newVanityMatcher(*chain, *prefix, *suffix, *expr)
+
+	if err != nil {
+		return err
+	}
+
+	if *workers < 1 || *count < 1 {
+		return errors.New("-workers and -count must be positive")
+	}
+
+	if err := os.MkdirAll(*out, 0700); err != nil {
+		return err
+	}
+
+	password, err := readNewPassword("Keystore password: ")
+
+	if err != nil {
+		return err
+	}
+
+	difficulty, known := matcher.difficulty()
+
+	if known {
+		fmt.Fprintf(os.Stderr, "difficulty: 1 in %.0f\n", difficulty)
+	} else {
+		fmt.Fprintln(os.Stderr, "difficulty: unknown for regex patterns")
+	}
+
+	var attempts uint64
+
+	hits := make(chan *vanityKey)
+	errs := make(chan error, *workers)
+	done := make(chan struct{})
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < *workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+
+				key, err := newVanityKey(*chain)
+
+				if err != nil {
+					errs <- err
+					return
+				}
+
+				atomic.AddUint64(&attempts, 1)
+
+				if !matcher.match(key.Address) {
+					continue
+				}
+
+				select {
+				case hits <- key:
+				case <-done:
+					return
+				}
+			}
+		}()
+	}
+
+	defer func() {
+		close(done)
+		wg.Wait()
+	}()
+
+	start := time.Now()
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+
+	for found := 0; found < *count; {
+		select {
+		case err := <-errs:
+			return err
+		case key := <-hits:
+			data, err := key.Write(password)
+
+			if err != nil {
+				return err
+			}
+
+			path := filepath.Join(*out, key.Address+".json")
+
+			if err := ioutil.WriteFile(path, data, 0600); err != nil {
+				return err
+			}
+
+			found++
+
+			fmt.Printf("%s %s\n", key.Address, path)
+		case <-ticker.C:
+			printVanityProgress(atomic.LoadUint64(&attempts), time.Since(start), difficulty, known)
+		}
+	}
+
+	printVanityProgress(atomic.LoadUint64(&attempts), time.Since(start), difficulty, known)
+
+	return nil
+}
+
+func printVanityProgress(attempts uint64, elapsed time.Duration, difficulty float64, known bool) {
+	rate := float64(attempts) / elapsed.Seconds()
+
+	if !known || rate == 0 {
+		fmt.Fprintf(os.Stderr, "%d keys, %.0f keys/s\n", attempts, rate)
+		return
+	}
+
+	seconds := difficulty / rate
+
+	if seconds > 3600*24*365 {
+		fmt.Fprintf(os.Stderr, "%d keys, %.0f keys/s, expected %.3g years per match\n", attempts, rate, seconds/(3600*24*365))
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "%d keys, %.0f keys/s, expected %s per match\n", attempts, rate, time.Duration(seconds*float64(time.Second)))
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	neokeystore "github.com/inwecrypto/neogo/keystore"
+	neotx "github.com/inwecrypto/neogo/tx"
+)
+
+func init() {
+	registerCommand(&command{
+		Name:  "vote",
+		Usage: "sign a NEO state transaction voting for validator candidates offline, no candidates cancels the votes",
+		Run:   runVote,
+	})
+}
+
+func runVote(args []string) error {
+	flags := flag.NewFlagSet("vote", flag.ExitOnError)
+
+	keystoreFile := flags.String("keystore", "", "keystore or NEP-2 file of the voting account")
+
+	flags.Parse(args)
+
+	var candidates [][]byte
+
+	for _, arg := range flags.Args() {
+		candidate, err := hex.DecodeString(arg)
+
+		if err != nil {
+			return fmt.Errorf("invalid public key %s", arg)
+		}
+
+		candidates = append(candidates, candidate)
+	}
+
+	key, err := readNEOKey(*keystoreFile)
+
+	if err != nil {
+		return err
+	}
+
+	scriptHash, err := neokeystore.PrivateToScriptHash(key.PrivateKey)
+
+	if err != nil {
+		return err
+	}
+
+	nonce, _ := time.Now().MarshalBinary()
+
+	tx, err := neotx.NewVoteTx(scriptHash, candidates, nonce)
+
+	if err != nil {
+		return err
+	}
+
+	rawTx, txid, err := tx.Tx().Sign(key.PrivateKey)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "%s votes for %d candidates\ntxid: %s\n", key.Address, len(candidates), txid)
+	fmt.Println(hex.EncodeToString(rawTx))
+
+	return nil
+}
@@ -1,32 +1,47 @@
 package main
 
 import (
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 
 	"github.com/asticode/go-astilectron"
 	"github.com/asticode/go-astilectron-bootstrap"
 
+	"github.com/inwecrypto/bip32"
+	"github.com/inwecrypto/keystore"
+	"github.com/inwecrypto/mobilesdk/ethmobile"
 	"github.com/inwecrypto/mobilesdk/neomobile"
+	neokeystore "github.com/inwecrypto/neogo/keystore"
 )
 
+// hdKey bip44 derivation result displayed by the gui
+type hdKey struct {
+	PrivateKey  string `json:"privateKey"`
+	Address     string `json:"address"`
+	Path        string `json:"path"`
+	Fingerprint string `json:"fingerprint"`
+}
+
 // handleMessages handles messages
 func handleMessages(_ *astilectron.Window, m bootstrap.MessageIn) (payload interface{}, err error) {
 
 	switch m.Name {
 	case "fromkeystore":
-        var ks [] string
+		var ks []string
 		if len(m.Payload) > 0 {
 			if err = json.Unmarshal(m.Payload, &ks); err != nil {
 				payload = err.Error()
 				return
 			}
 		}
-		if payload, err = neomobile.FromKeyStore(ks[0], ks[1]); err != nil {
-			payload = err.Error()
+		if payload, err = fromKeyStore(ks[0], ks[1]); err != nil {
+			payload = keystoreErrorMessage(err)
 			return
 		}
 	case "frommnemonic":
-        var mc [] string
+		// payload: mnemonic, lang[, derivation, chain, path, passphrase]
+		var mc []string
 		if len(m.Payload) > 0 {
 			// Unmarshal payload
 			if err = json.Unmarshal(m.Payload, &mc); err != nil {
@@ -35,11 +50,89 @@ func handleMessages(_ *astilectron.Window, m bootstrap.MessageIn) (payload inter
 			}
 		}
 
+		if len(mc) == 6 && mc[2] == "bip44" {
+			if payload, err = fromMnemonicPath(mc[0], mc[1], mc[3], mc[4], mc[5]); err != nil {
+				payload = err.Error()
+			}
+			return
+		}
+
 		if payload, err = neomobile.FromMnemonic(mc[0], mc[1]); err != nil {
 			payload = err.Error()
 			return
 		}
+	}
 	return
 }
-return 
-}
\ No newline at end of file
+
+func fromMnemonicPath(mnemonic, lang, chain, path, passphrase string) (*hdKey, error) {
+	switch chain {
+	case "neo":
+		if path == "" {
+			path = bip32.BIP44Path(bip32.CoinTypeNEO, 0, 0).String()
+		}
+
+		key, err := neomobile.FromMnemonicPath(mnemonic, lang, path, passphrase)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &hdKey{
+			PrivateKey:  key.PrivateKey,
+			Address:     key.Address,
+			Path:        path,
+			Fingerprint: key.Fingerprint,
+		}, nil
+	case "eth":
+		if path == "" {
+			path = bip32.BIP44Path(bip32.CoinTypeETH, 0, 0).String()
+		}
+
+		wallet, err := ethmobile.FromMnemonicPath(mnemonic, lang, path, passphrase)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &hdKey{
+			PrivateKey:  wallet.PrivateKey(),
+			Address:     wallet.Address(),
+			Path:        path,
+			Fingerprint: wallet.Fingerprint(),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unknown chain %s", chain)
+}
+
+// fromKeyStore read the neo private key through the top level keystore package,
+// mobilesdk vendors its own copy whose error types keystoreErrorMessage can not match
+func fromKeyStore(ks, password string) (string, error) {
+	key, err := neokeystore.ReadKeyStore([]byte(ks), password)
+
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(key.PrivateKey.D.Bytes()), nil
+}
+
+// keystoreErrorMessage tell the user whether the password, the keystore file or its format is wrong
+func keystoreErrorMessage(err error) string {
+	switch e := err.(type) {
+	case *keystore.ErrMalformedJSON:
+		return "The keystore file is damaged or not a keystore: " + e.Error()
+	case *keystore.ErrUnsupportedCipher, *keystore.ErrUnsupportedKDF, *keystore.ErrVersion, *keystore.ErrUnsupportedFormat:
+		return "This keystore format is not supported: " + e.Error()
+	}
+
+	switch err {
+	case keystore.ErrWrongPassword:
+		return "Wrong keystore password."
+	case keystore.ErrMACMismatch:
+		return "The keystore file is damaged, its checksum can never match."
+	}
+
+	return err.Error()
+}
@@ -18,19 +18,37 @@ let index = {
         };
     
     },
+    checked(name) {
+        var obj = document.getElementsByName(name);
+        for(var i=0; i<obj.length; i ++){
+            if(obj[i].checked){
+                return obj[i].value;
+                };
+            };
+        return "";
+    },
     fromMnemonic() {
+        var obj = document.getElementsByName("derivation");
+        for(var i=0; i<obj.length; i ++){
+            obj[i].onchange = function() {
+                document.getElementById("bip44").style.display = index.checked("derivation") === "bip44" ? "block" : "none";
+            };
+        };
+        obj = document.getElementsByName("chain");
+        for(var i=0; i<obj.length; i ++){
+            obj[i].onchange = function() {
+                document.getElementById("path").placeholder = index.checked("chain") === "eth" ? "m/44'/60'/0'/0/0" : "m/44'/888'/0'/0/0";
+            };
+        };
         let mcsubmit = document.getElementById("mcsubmit");
         mcsubmit.onclick = function() {
             let mnemonic = document.getElementById("mc").value;
-            let lang=""
-            var obj = document.getElementsByName("lang");
-            for(var i=0; i<obj.length; i ++){
-                if(obj[i].checked){
-                    lang = obj[i].value;
-                    };
-                };
+            let lang = index.checked("lang");
             let message = {"name":"frommnemonic"};
             let payload = [mnemonic,lang];
+            if (index.checked("derivation") === "bip44") {
+                payload.push("bip44", index.checked("chain"), document.getElementById("path").value, document.getElementById("passphrase").value);
+            }
             message.payload = payload;
             index.explore(message); 
             };
@@ -62,11 +80,17 @@ let index = {
 
             // Check error
             if (message.name === "error") {
-                asticode.notifier.error(message.payload);
+                // mnemonic diagnostics report one word per line
+                asticode.notifier.error(String(message.payload).replace(/\n/g, "<br>"));
                 return
             }
             // Process path
-            document.getElementById("pk").innerHTML = message.payload;
+            let result = message.payload;
+            if (typeof result === "object") {
+                // check the fingerprint and address against the original wallet, a mistyped passphrase gives a different wallet
+                result = "master fingerprint: " + result.fingerprint + "<br>path: " + result.path + "<br>address: " + result.address + "<br>private key: " + result.privateKey;
+            }
+            document.getElementById("pk").innerHTML = result;
         })
     },
     listen: function() {